@@ -2,13 +2,17 @@ package main
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"log"
+	"math/rand"
+	"net"
 	"net/http"
 	"net/url"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/go-cleanhttp"
 	"github.com/hashicorp/terraform/helper/schema"
@@ -25,6 +29,16 @@ type AlksClient struct {
 	Account AlksAccount
 	BaseURL string
 
+	// MaxRetries bounds how many times a request is retried after a
+	// 429/5xx response or a connection error, with exponential backoff
+	// and jitter between attempts. It's set from the provider's
+	// max_retries attribute.
+	MaxRetries int
+
+	// Auth signs each outgoing request. Defaults to PasswordAuth, which
+	// relies on the credentials already embedded in Account.
+	Auth AuthProvider
+
 	Http *http.Client
 }
 
@@ -38,6 +52,14 @@ type CreateIamRoleReq struct {
 	IncDefPols int    `json:"includeDefaultPolicy"`
 }
 
+type CreateIamTrustRoleReq struct {
+	RoleName          string `json:"roleName"`
+	RoleType          string `json:"roleType"`
+	IncDefPols        int    `json:"includeDefaultPolicy"`
+	AssumeRolePolicy  string `json:"assumeRolePolicyDocument"`
+	MaxSessionSeconds int    `json:"maxSessionDuration,omitempty"`
+}
+
 type StsResponse struct {
 	AccessKey    string `json:"accessKey"`
 	SessionKey   string `json:"secretKey"`
@@ -75,7 +97,123 @@ type DeleteRoleResponse struct {
 	Errors   []string `json:"errors"`
 }
 
-func NewAlksClient(url string, username string, password string, account string, role string) (*AlksClient, error) {
+type AttachRolePolicyReq struct {
+	RoleName       string `json:"roleName"`
+	PolicyName     string `json:"policyName"`
+	PolicyDocument string `json:"policyDocument"`
+}
+
+type AttachRolePolicyResponse struct {
+	RoleName   string   `json:"roleName"`
+	PolicyName string   `json:"policyName"`
+	Errors     []string `json:"errors"`
+}
+
+type GetRolePolicyRequest struct {
+	RoleName   string `json:"roleName"`
+	PolicyName string `json:"policyName"`
+}
+
+type GetRolePolicyResponse struct {
+	RoleName       string   `json:"roleName"`
+	PolicyName     string   `json:"policyName"`
+	PolicyDocument string   `json:"policyDocument"`
+	Exists         bool     `json:"policyExists"`
+	Errors         []string `json:"errors"`
+}
+
+type DetachRolePolicyRequest struct {
+	RoleName   string `json:"roleName"`
+	PolicyName string `json:"policyName"`
+}
+
+type DetachRolePolicyResponse struct {
+	RoleName   string   `json:"roleName"`
+	PolicyName string   `json:"policyName"`
+	Errors     []string `json:"errors"`
+}
+
+type ListRolePoliciesRequest struct {
+	RoleName string `json:"roleName"`
+}
+
+type ListRolePoliciesResponse struct {
+	RoleName    string   `json:"roleName"`
+	PolicyNames []string `json:"policyNames"`
+	Errors      []string `json:"errors"`
+}
+
+// RolePolicy represents an inline IAM policy document attached to an
+// ALKS-created role.
+type RolePolicy struct {
+	RoleName       string
+	PolicyName     string
+	PolicyDocument string
+}
+
+type CreateLongTermKeyReq struct {
+	IamUserName string `json:"iamUserName"`
+}
+
+type GetLongTermKeyRequest struct {
+	IamUserName string `json:"iamUserName"`
+}
+
+type LongTermKeyResponse struct {
+	IamUserName string   `json:"iamUserName"`
+	AccessKey   string   `json:"accessKey"`
+	SecretKey   string   `json:"secretKey"`
+	IamUserArn  string   `json:"iamUserArn"`
+	Exists      bool     `json:"iamUserExists"`
+	Errors      []string `json:"errors"`
+}
+
+type DeleteLongTermKeyRequest struct {
+	IamUserName string `json:"iamUserName"`
+}
+
+type DeleteLongTermKeyResponse struct {
+	IamUserName string   `json:"iamUserName"`
+	Errors      []string `json:"errors"`
+}
+
+// AlksError is returned for any ALKS response whose status is not 200,
+// 201, 202 or 204. It carries the
+// HTTP status code alongside the `errors` envelope ALKS returns, so
+// callers can pattern-match on Messages (e.g. "role does not exist")
+// instead of scraping a formatted string.
+type AlksError struct {
+	StatusCode int
+	Code       string
+	Messages   []string
+}
+
+func (e *AlksError) Error() string {
+	if len(e.Messages) > 0 {
+		return fmt.Sprintf("API Error %d: %s", e.StatusCode, strings.Join(e.Messages, ", "))
+	}
+	return fmt.Sprintf("API Error %d", e.StatusCode)
+}
+
+// HasMessage reports whether any of the error envelope's messages
+// contains the given substring, case-insensitively.
+func (e *AlksError) HasMessage(substr string) bool {
+	substr = strings.ToLower(substr)
+	for _, m := range e.Messages {
+		if strings.Contains(strings.ToLower(m), substr) {
+			return true
+		}
+	}
+	return false
+}
+
+const defaultMaxRetries = 3
+
+func NewAlksClient(url string, username string, password string, account string, role string, maxRetries int, auth AuthProvider) (*AlksClient, error) {
+	if auth == nil {
+		auth = PasswordAuth{}
+	}
+
 	alksClient := AlksClient{
 		Account: AlksAccount{
 			Username: username,
@@ -83,21 +221,23 @@ func NewAlksClient(url string, username string, password string, account string,
 			Account:  account,
 			Role:     role,
 		},
-		BaseURL: url,
-		Http:    cleanhttp.DefaultClient(),
+		BaseURL:    url,
+		MaxRetries: maxRetries,
+		Auth:       auth,
+		Http:       cleanhttp.DefaultClient(),
 	}
 
 	return &alksClient, nil
 }
 
-func (c *AlksClient) NewRequest(json []byte, method string, endpoint string) (*http.Request, error) {
+func (c *AlksClient) NewRequest(ctx context.Context, json []byte, method string, endpoint string) (*http.Request, error) {
 	u, err := url.Parse(c.BaseURL + endpoint)
 
 	if err != nil {
 		return nil, fmt.Errorf("Error parsing base URL: %s", err)
 	}
 
-	req, err := http.NewRequest(method, u.String(), bytes.NewBuffer(json))
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), bytes.NewBuffer(json))
 
 	if err != nil {
 		return nil, fmt.Errorf("Error creating request: %s", err)
@@ -105,6 +245,10 @@ func (c *AlksClient) NewRequest(json []byte, method string, endpoint string) (*h
 
 	req.Header.Set("Content-Type", "application/json")
 
+	if err := c.Auth.Sign(req); err != nil {
+		return nil, err
+	}
+
 	return req, nil
 }
 
@@ -122,66 +266,160 @@ func decodeBody(resp *http.Response, out interface{}) error {
 	return nil
 }
 
+// checkResp classifies the response: 200, 201, 202 and 204 are passed
+// through untouched, anything else has its `errors` envelope parsed once into an *AlksError
+// so callers get structured status/messages instead of a bare string.
 func checkResp(resp *http.Response, err error) (*http.Response, error) {
 	if err != nil {
 		return resp, err
 	}
 
 	switch i := resp.StatusCode; {
-	case i == 200:
+	case i == 200, i == 201, i == 202, i == 204:
 		return resp, nil
-	case i == 201:
-		return resp, nil
-	case i == 202:
-		return resp, nil
-	case i == 204:
-		return resp, nil
-	case i == 400:
-		return nil, fmt.Errorf("API Error 400: %s", resp.Status)
-	case i == 401:
-		return nil, fmt.Errorf("API Error 401: %s", resp.Status)
-	case i == 402:
-		return nil, fmt.Errorf("API Error 402: %s", resp.Status)
-	case i == 422:
-		return nil, fmt.Errorf("API Error 422: %s", resp.Status)
 	default:
-		return nil, fmt.Errorf("API Error: %s", resp.Status)
+		defer resp.Body.Close()
+
+		var envelope struct {
+			Errors []string `json:"errors"`
+		}
+
+		body, readErr := ioutil.ReadAll(resp.Body)
+		if readErr == nil {
+			// Best-effort: if ALKS didn't return the usual JSON envelope,
+			// Messages is simply empty and Error() falls back to the status.
+			json.Unmarshal(body, &envelope)
+		}
+
+		var code string
+		if len(envelope.Errors) > 0 {
+			code = errorCode(envelope.Errors[0])
+		}
+
+		return nil, &AlksError{
+			StatusCode: resp.StatusCode,
+			Code:       code,
+			Messages:   envelope.Errors,
+		}
 	}
 }
 
-func (c *AlksClient) CreateIamKey() (*StsResponse, error) {
+// errorCode pulls a leading "Code: message" style token off an ALKS
+// error string, mirroring how the AWS SDK splits awserr.Code from the
+// message (e.g. "NoSuchEntity: role does not exist" -> "NoSuchEntity").
+// Messages without that shape yield an empty code.
+func errorCode(msg string) string {
+	if idx := strings.Index(msg, ":"); idx > 0 {
+		return strings.TrimSpace(msg[:idx])
+	}
+	return ""
+}
 
-	iam := CreateIamKeyReq{1}
-	b, err := json.Marshal(struct {
-		CreateIamKeyReq
-		AlksAccount
-	}{iam, c.Account})
+// isRetryable reports whether a failed request is worth retrying:
+// 429/5xx responses from ALKS, or a transient network error such as a
+// connection reset or timeout while the request was in flight.
+func isRetryable(err error) bool {
+	if err == nil {
+		return false
+	}
 
-	if err != nil {
-		return nil, fmt.Errorf("Error encoding IAM create key JSON: %s", err)
+	if alksErr, ok := err.(*AlksError); ok {
+		return alksErr.StatusCode == http.StatusTooManyRequests || alksErr.StatusCode >= 500
+	}
+
+	if netErr, ok := err.(net.Error); ok && netErr.Timeout() {
+		return true
+	}
+
+	return strings.Contains(err.Error(), "connection reset") || strings.Contains(err.Error(), "EOF")
+}
+
+// backoff returns the exponential delay with jitter for the given retry
+// attempt (0-indexed). The exponential base is capped at 10 seconds and
+// up to half of it is added as jitter, so the delay never exceeds 15s.
+func backoff(attempt int) time.Duration {
+	base := time.Duration(1<<uint(attempt)) * 250 * time.Millisecond
+	if base > 10*time.Second {
+		base = 10 * time.Second
 	}
+	jitter := time.Duration(rand.Int63n(int64(base) / 2))
+	return base + jitter
+}
 
-	req, err := c.NewRequest(b, "POST", "/getIAMKeys/")
+// doRequest marshals payload alongside the account envelope ALKS expects
+// on every call, then executes it with exponential backoff + jitter
+// retries on 429/5xx responses and transient network errors. maxRetries
+// additional attempts are made beyond the first, so MaxRetries=0 keeps
+// today's single-attempt behavior.
+func (c *AlksClient) doRequest(ctx context.Context, method string, endpoint string, payload interface{}) (*http.Response, error) {
+	b, err := json.Marshal(payload)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("Error encoding request JSON: %s", err)
+	}
+
+	var resp *http.Response
+	var lastErr error
+
+	// c.MaxRetries is user-configurable (provider's max_retries attribute);
+	// a negative value must not skip the loop body entirely, so the bound
+	// is clamped to 0 rather than trusted as-is.
+	maxRetries := c.MaxRetries
+	if maxRetries < 0 {
+		maxRetries = 0
+	}
+
+	for attempt := 0; attempt <= maxRetries; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			case <-time.After(backoff(attempt - 1)):
+			}
+		}
+
+		req, err := c.NewRequest(ctx, b, method, endpoint)
+		if err != nil {
+			return nil, err
+		}
+
+		resp, lastErr = checkResp(c.Http.Do(req))
+		if lastErr == nil {
+			return resp, nil
+		}
+
+		if !isRetryable(lastErr) {
+			return nil, lastErr
+		}
+
+		log.Printf("[WARN] Retryable error calling %s (attempt %d/%d): %s", endpoint, attempt+1, maxRetries+1, lastErr)
 	}
 
-	resp, err := checkResp(c.Http.Do(req))
+	return nil, lastErr
+}
+
+// CreateIamKey requests a short-lived STS key. The request always sends a
+// fixed sessionTime of 1; the session duration cannot be set by the
+// caller, and the provider has no attribute for it.
+func (c *AlksClient) CreateIamKey(ctx context.Context) (*StsResponse, error) {
+	iam := CreateIamKeyReq{1}
+
+	resp, err := c.doRequest(ctx, "POST", "/getIAMKeys/", struct {
+		CreateIamKeyReq
+		AlksAccount
+	}{iam, c.Account})
 	if err != nil {
 		return nil, err
 	}
 
 	sts := new(StsResponse)
-	err = decodeBody(resp, &sts)
-
-	if err != nil {
+	if err := decodeBody(resp, &sts); err != nil {
 		return nil, fmt.Errorf("Error parsing STS response: %s", err)
 	}
 
 	return sts, nil
 }
 
-func (c *AlksClient) CreateIamRole(roleName string, roleType string, includeDefaultPolicies bool) (*CreateRoleResponse, error) {
+func (c *AlksClient) CreateIamRole(ctx context.Context, roleName string, roleType string, includeDefaultPolicies bool) (*CreateRoleResponse, error) {
 	var include int = 0
 	if includeDefaultPolicies {
 		include = 1
@@ -193,115 +431,369 @@ func (c *AlksClient) CreateIamRole(roleName string, roleType string, includeDefa
 		include,
 	}
 
-	b, err := json.Marshal(struct {
+	resp, err := c.doRequest(ctx, "POST", "/createRole/", struct {
 		CreateIamRoleReq
 		AlksAccount
 	}{iam, c.Account})
-
 	if err != nil {
-		return nil, fmt.Errorf("Error encoding IAM create role JSON: %s", err)
+		return nil, err
 	}
 
-	req, err := c.NewRequest(b, "POST", "/createRole/")
-	if err != nil {
-		return nil, err
+	cr := new(CreateRoleResponse)
+	if err := decodeBody(resp, &cr); err != nil {
+		return nil, fmt.Errorf("Error parsing CreateRole response: %s", err)
+	}
+
+	if len(cr.Errors) > 0 {
+		return nil, fmt.Errorf("Error creating role: %s", strings.Join(cr.Errors[:], ", "))
+	}
+
+	return cr, nil
+}
+
+// CreateIamTrustRole creates an IAM role using a caller-supplied
+// assume-role policy document instead of the fixed role type ALKS would
+// otherwise generate, so cross-account trust, SAML federation, and
+// extended STS sessions are reachable from the alks_iam_role resource.
+func (c *AlksClient) CreateIamTrustRole(ctx context.Context, roleName string, roleType string, assumeRolePolicy string, maxSessionSeconds int, includeDefaultPolicies bool) (*CreateRoleResponse, error) {
+	var include int = 0
+	if includeDefaultPolicies {
+		include = 1
+	}
+
+	iam := CreateIamTrustRoleReq{
+		RoleName:          roleName,
+		RoleType:          roleType,
+		IncDefPols:        include,
+		AssumeRolePolicy:  assumeRolePolicy,
+		MaxSessionSeconds: maxSessionSeconds,
 	}
 
-	resp, err := checkResp(c.Http.Do(req))
+	resp, err := c.doRequest(ctx, "POST", "/createRole/", struct {
+		CreateIamTrustRoleReq
+		AlksAccount
+	}{iam, c.Account})
 	if err != nil {
 		return nil, err
 	}
 
 	cr := new(CreateRoleResponse)
-	err = decodeBody(resp, &cr)
-
-	if err != nil {
+	if err := decodeBody(resp, &cr); err != nil {
 		return nil, fmt.Errorf("Error parsing CreateRole response: %s", err)
 	}
 
 	if len(cr.Errors) > 0 {
-		return nil, fmt.Errorf("Error creating role: %s", strings.Join(cr.Errors[:], ", "))
+		return nil, fmt.Errorf("Error creating trust role: %s", strings.Join(cr.Errors[:], ", "))
 	}
 
 	return cr, nil
 }
 
-func (c *AlksClient) DeleteIamRole(d *schema.ResourceData, meta interface{}) error {
+func (c *AlksClient) DeleteIamRole(ctx context.Context, d *schema.ResourceData, meta interface{}) error {
 	log.Printf("[INFO] Deleting IAM role: %s", d.Id())
 
 	rmRole := DeleteRoleRequest{d.Id()}
 
-	b, err := json.Marshal(struct {
+	resp, err := c.doRequest(ctx, "POST", "/deleteRole/", struct {
 		DeleteRoleRequest
 		AlksAccount
 	}{rmRole, c.Account})
-
-	if err != nil {
-		return fmt.Errorf("Error encoding IAM delete role JSON: %s", err)
-	}
-
-	req, err := c.NewRequest(b, "POST", "/deleteRole/")
-	if err != nil {
-		return err
-	}
-
-	resp, err := checkResp(c.Http.Do(req))
 	if err != nil {
+		if alksErr, ok := err.(*AlksError); ok && alksErr.HasMessage("does not exist") {
+			// Already gone: destroy and drift-detection are idempotent.
+			return nil
+		}
 		return err
 	}
 
 	del := new(DeleteRoleResponse)
-	err = decodeBody(resp, &del)
-
-	if err != nil {
+	if err := decodeBody(resp, &del); err != nil {
 		return fmt.Errorf("Error parsing DeleteRole response: %s", err)
 	}
 
-	// TODO you get an error if you delete an already deleted role, need to revist for checking fail/success
 	if len(del.Errors) > 0 {
+		if strings.Contains(strings.Join(del.Errors[:], ", "), "does not exist") {
+			return nil
+		}
 		return fmt.Errorf("Error deleting role: %s", strings.Join(del.Errors[:], ", "))
 	}
 
 	return nil
 }
 
-func (c *AlksClient) GetIamRole(roleName string) (*GetRoleResponse, error) {
+func (c *AlksClient) GetIamRole(ctx context.Context, roleName string) (*GetRoleResponse, error) {
 	log.Printf("[INFO] Getting IAM role: %s", roleName)
 	getRole := GetRoleRequest{roleName}
 
-	b, err := json.Marshal(struct {
+	resp, err := c.doRequest(ctx, "POST", "/getAccountRole/", struct {
 		GetRoleRequest
 		AlksAccount
 	}{getRole, c.Account})
+	if err != nil {
+		if alksErr, ok := err.(*AlksError); ok && alksErr.HasMessage("does not exist") {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	cr := new(GetRoleResponse)
+	if err := decodeBody(resp, &cr); err != nil {
+		return nil, fmt.Errorf("Error parsing GetRole response: %s", err)
+	}
+
+	if len(cr.Errors) > 0 {
+		if strings.Contains(strings.Join(cr.Errors[:], ", "), "does not exist") {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("Error getting role: %s", strings.Join(cr.Errors[:], ", "))
+	}
+
+	if !cr.Exists {
+		return nil, nil
+	}
+
+	return cr, nil
+}
+
+// AttachRolePolicy creates or updates the inline policy document on an
+// IAM role. ALKS treats this as an upsert, so it's used for both the
+// Create and Update paths of the alks_iam_role_policy resource.
+func (c *AlksClient) AttachRolePolicy(ctx context.Context, roleName string, policyName string, policyDocument string) (*AttachRolePolicyResponse, error) {
+	log.Printf("[INFO] Attaching IAM role policy: %s/%s", roleName, policyName)
+
+	attach := AttachRolePolicyReq{
+		RoleName:       roleName,
+		PolicyName:     policyName,
+		PolicyDocument: policyDocument,
+	}
+
+	resp, err := c.doRequest(ctx, "POST", "/attachRolePolicy/", struct {
+		AttachRolePolicyReq
+		AlksAccount
+	}{attach, c.Account})
+	if err != nil {
+		return nil, err
+	}
+
+	ar := new(AttachRolePolicyResponse)
+	if err := decodeBody(resp, &ar); err != nil {
+		return nil, fmt.Errorf("Error parsing AttachRolePolicy response: %s", err)
+	}
+
+	if len(ar.Errors) > 0 {
+		return nil, fmt.Errorf("Error attaching role policy: %s", strings.Join(ar.Errors[:], ", "))
+	}
+
+	return ar, nil
+}
+
+// GetRolePolicy fetches a single inline policy document on a role. ALKS
+// returns the document URL-encoded, matching how AWS returns it from
+// GetRolePolicy; it is unescaped here, so the returned PolicyDocument is
+// plain JSON.
+func (c *AlksClient) GetRolePolicy(ctx context.Context, roleName string, policyName string) (*RolePolicy, error) {
+	log.Printf("[INFO] Getting IAM role policy: %s/%s", roleName, policyName)
+
+	getPolicy := GetRolePolicyRequest{
+		RoleName:   roleName,
+		PolicyName: policyName,
+	}
+
+	resp, err := c.doRequest(ctx, "POST", "/getRolePolicy/", struct {
+		GetRolePolicyRequest
+		AlksAccount
+	}{getPolicy, c.Account})
+	if err != nil {
+		if alksErr, ok := err.(*AlksError); ok && alksErr.Code == "NoSuchEntity" {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	gr := new(GetRolePolicyResponse)
+	if err := decodeBody(resp, &gr); err != nil {
+		return nil, fmt.Errorf("Error parsing GetRolePolicy response: %s", err)
+	}
+
+	if len(gr.Errors) > 0 {
+		// NoSuchEntity: the role or policy is gone, let Read clear the ID.
+		if strings.Contains(strings.Join(gr.Errors[:], ", "), "NoSuchEntity") {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("Error getting role policy: %s", strings.Join(gr.Errors[:], ", "))
+	}
+
+	if !gr.Exists {
+		return nil, nil
+	}
+
+	document, err := url.QueryUnescape(gr.PolicyDocument)
+	if err != nil {
+		return nil, fmt.Errorf("Error decoding PolicyDocument: %s", err)
+	}
+
+	return &RolePolicy{
+		RoleName:       gr.RoleName,
+		PolicyName:     gr.PolicyName,
+		PolicyDocument: document,
+	}, nil
+}
+
+// DetachRolePolicy removes an inline policy document from a role.
+func (c *AlksClient) DetachRolePolicy(ctx context.Context, roleName string, policyName string) error {
+	log.Printf("[INFO] Detaching IAM role policy: %s/%s", roleName, policyName)
+
+	detach := DetachRolePolicyRequest{
+		RoleName:   roleName,
+		PolicyName: policyName,
+	}
 
+	resp, err := c.doRequest(ctx, "POST", "/detachRolePolicy/", struct {
+		DetachRolePolicyRequest
+		AlksAccount
+	}{detach, c.Account})
 	if err != nil {
-		return nil, fmt.Errorf("Error encoding IAM create role JSON: %s", err)
+		if alksErr, ok := err.(*AlksError); ok && alksErr.Code == "NoSuchEntity" {
+			return nil
+		}
+		return err
+	}
+
+	dr := new(DetachRolePolicyResponse)
+	if err := decodeBody(resp, &dr); err != nil {
+		return fmt.Errorf("Error parsing DetachRolePolicy response: %s", err)
+	}
+
+	if len(dr.Errors) > 0 {
+		// NoSuchEntity: already gone, nothing left to do.
+		if strings.Contains(strings.Join(dr.Errors[:], ", "), "NoSuchEntity") {
+			return nil
+		}
+		return fmt.Errorf("Error detaching role policy: %s", strings.Join(dr.Errors[:], ", "))
 	}
 
-	req, err := c.NewRequest(b, "POST", "/getAccountRole/")
+	return nil
+}
+
+// ListRolePolicies lists the names of the inline policies attached to a role.
+func (c *AlksClient) ListRolePolicies(ctx context.Context, roleName string) (*ListRolePoliciesResponse, error) {
+	log.Printf("[INFO] Listing IAM role policies: %s", roleName)
+
+	listPolicies := ListRolePoliciesRequest{roleName}
+
+	resp, err := c.doRequest(ctx, "POST", "/listRolePolicies/", struct {
+		ListRolePoliciesRequest
+		AlksAccount
+	}{listPolicies, c.Account})
 	if err != nil {
 		return nil, err
 	}
 
-	resp, err := checkResp(c.Http.Do(req))
+	lr := new(ListRolePoliciesResponse)
+	if err := decodeBody(resp, &lr); err != nil {
+		return nil, fmt.Errorf("Error parsing ListRolePolicies response: %s", err)
+	}
+
+	if len(lr.Errors) > 0 {
+		return nil, fmt.Errorf("Error listing role policies: %s", strings.Join(lr.Errors[:], ", "))
+	}
+
+	return lr, nil
+}
+
+// CreateLongTermKey creates a long-term IAM user and access key pair.
+// The secret key is only ever returned here, on creation, matching how
+// AWS's CreateAccessKey works; it's never fetched again on Read.
+func (c *AlksClient) CreateLongTermKey(ctx context.Context, iamUserName string) (*LongTermKeyResponse, error) {
+	log.Printf("[INFO] Creating long-term IAM key: %s", iamUserName)
+
+	create := CreateLongTermKeyReq{IamUserName: iamUserName}
+
+	resp, err := c.doRequest(ctx, "POST", "/accessKeys/", struct {
+		CreateLongTermKeyReq
+		AlksAccount
+	}{create, c.Account})
 	if err != nil {
 		return nil, err
 	}
 
-	cr := new(GetRoleResponse)
-	err = decodeBody(resp, &cr)
+	lt := new(LongTermKeyResponse)
+	if err := decodeBody(resp, &lt); err != nil {
+		return nil, fmt.Errorf("Error parsing CreateLongTermKey response: %s", err)
+	}
+
+	if len(lt.Errors) > 0 {
+		return nil, fmt.Errorf("Error creating long-term IAM key: %s", strings.Join(lt.Errors[:], ", "))
+	}
+
+	return lt, nil
+}
+
+// GetLongTermKey verifies that a long-term IAM user still exists. It
+// does not return the secret key: ALKS only hands that back at creation.
+func (c *AlksClient) GetLongTermKey(ctx context.Context, iamUserName string) (*LongTermKeyResponse, error) {
+	log.Printf("[INFO] Getting long-term IAM key: %s", iamUserName)
 
+	getKey := GetLongTermKeyRequest{IamUserName: iamUserName}
+
+	resp, err := c.doRequest(ctx, "POST", "/getAccessKeys/", struct {
+		GetLongTermKeyRequest
+		AlksAccount
+	}{getKey, c.Account})
 	if err != nil {
-		return nil, fmt.Errorf("Error parsing GetRole response: %s", err)
+		if alksErr, ok := err.(*AlksError); ok && alksErr.HasMessage("does not exist") {
+			return nil, nil
+		}
+		return nil, err
 	}
 
-	if len(cr.Errors) > 0 {
-		return nil, fmt.Errorf("Error getting role: %s", strings.Join(cr.Errors[:], ", "))
+	lt := new(LongTermKeyResponse)
+	if err := decodeBody(resp, &lt); err != nil {
+		return nil, fmt.Errorf("Error parsing GetLongTermKey response: %s", err)
 	}
 
-	if !cr.Exists {
+	if len(lt.Errors) > 0 {
+		if strings.Contains(strings.Join(lt.Errors[:], ", "), "does not exist") {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("Error getting long-term IAM key: %s", strings.Join(lt.Errors[:], ", "))
+	}
+
+	if !lt.Exists {
 		return nil, nil
 	}
 
-	return cr, nil
+	return lt, nil
+}
+
+// DeleteLongTermKey removes a long-term IAM user and its access key.
+func (c *AlksClient) DeleteLongTermKey(ctx context.Context, iamUserName string) error {
+	log.Printf("[INFO] Deleting long-term IAM key: %s", iamUserName)
+
+	del := DeleteLongTermKeyRequest{IamUserName: iamUserName}
+
+	resp, err := c.doRequest(ctx, "POST", "/deleteAccessKeys/", struct {
+		DeleteLongTermKeyRequest
+		AlksAccount
+	}{del, c.Account})
+	if err != nil {
+		if alksErr, ok := err.(*AlksError); ok && alksErr.HasMessage("does not exist") {
+			return nil
+		}
+		return err
+	}
+
+	dr := new(DeleteLongTermKeyResponse)
+	if err := decodeBody(resp, &dr); err != nil {
+		return fmt.Errorf("Error parsing DeleteLongTermKey response: %s", err)
+	}
+
+	if len(dr.Errors) > 0 {
+		if strings.Contains(strings.Join(dr.Errors[:], ", "), "does not exist") {
+			return nil
+		}
+		return fmt.Errorf("Error deleting long-term IAM key: %s", strings.Join(dr.Errors[:], ", "))
+	}
+
+	return nil
 }
@@ -0,0 +1,390 @@
+// Package alkstest implements an in-memory fake of the ALKS HTTP API for
+// use in acceptance tests, following the same httptest.Server-backed
+// fake pattern the iamtest package uses for the AWS IAM API.
+package alkstest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+)
+
+type role struct {
+	roleType string
+	arn      string
+	ipArn    string
+	policies map[string]string
+}
+
+type key struct {
+	accessKey string
+	secretKey string
+}
+
+type ltk struct {
+	accessKey string
+	secretKey string
+	arn       string
+}
+
+// Server is an httptest.Server backed by in-memory maps of roles and
+// keys, standing in for a real ALKS instance in acceptance tests.
+type Server struct {
+	*httptest.Server
+
+	mu      sync.Mutex
+	roles   map[string]*role
+	keys    []key
+	ltks    map[string]*ltk
+	errors  map[string]int
+	actions []string
+}
+
+// NewServer starts a fake ALKS server. Callers must Close it when done,
+// typically via defer.
+func NewServer() *Server {
+	s := &Server{
+		roles:  make(map[string]*role),
+		ltks:   make(map[string]*ltk),
+		errors: make(map[string]int),
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/getIAMKeys/", s.handleGetIAMKeys)
+	mux.HandleFunc("/createRole/", s.handleCreateRole)
+	mux.HandleFunc("/getAccountRole/", s.handleGetAccountRole)
+	mux.HandleFunc("/deleteRole/", s.handleDeleteRole)
+	mux.HandleFunc("/attachRolePolicy/", s.handleAttachRolePolicy)
+	mux.HandleFunc("/getRolePolicy/", s.handleGetRolePolicy)
+	mux.HandleFunc("/detachRolePolicy/", s.handleDetachRolePolicy)
+	mux.HandleFunc("/listRolePolicies/", s.handleListRolePolicies)
+	mux.HandleFunc("/accessKeys/", s.handleCreateAccessKeys)
+	mux.HandleFunc("/getAccessKeys/", s.handleGetAccessKeys)
+	mux.HandleFunc("/deleteAccessKeys/", s.handleDeleteAccessKeys)
+
+	s.Server = httptest.NewServer(s.recordAndFault(mux))
+
+	return s
+}
+
+// SetError makes the next requests to path fail with the given HTTP
+// status code until cleared with SetError(path, 0).
+func (s *Server) SetError(path string, status int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if status == 0 {
+		delete(s.errors, path)
+		return
+	}
+	s.errors[path] = status
+}
+
+// Actions returns the request paths recorded so far, in call order.
+func (s *Server) Actions() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	out := make([]string, len(s.actions))
+	copy(out, s.actions)
+	return out
+}
+
+func (s *Server) recordAndFault(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		s.actions = append(s.actions, r.URL.Path)
+		status := s.errors[r.URL.Path]
+		s.mu.Unlock()
+
+		if status != 0 {
+			w.WriteHeader(status)
+			json.NewEncoder(w).Encode(map[string][]string{
+				"errors": {fmt.Sprintf("injected fault: %d", status)},
+			})
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(v)
+}
+
+func decodeJSON(r *http.Request, v interface{}) error {
+	defer r.Body.Close()
+	return json.NewDecoder(r.Body).Decode(v)
+}
+
+func (s *Server) handleGetIAMKeys(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	k := key{
+		accessKey: fmt.Sprintf("AKIAFAKE%d", len(s.keys)),
+		secretKey: fmt.Sprintf("secret%d", len(s.keys)),
+	}
+	s.keys = append(s.keys, k)
+
+	writeJSON(w, map[string]interface{}{
+		"accessKey":    k.accessKey,
+		"secretKey":    k.secretKey,
+		"sessionToken": "faketoken",
+	})
+}
+
+func (s *Server) handleCreateRole(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		RoleName string `json:"roleName"`
+		RoleType string `json:"roleType"`
+	}
+	if err := decodeJSON(r, &req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	rl := &role{
+		roleType: req.RoleType,
+		arn:      fmt.Sprintf("arn:aws:iam::123456789012:role/%s", req.RoleName),
+		ipArn:    fmt.Sprintf("arn:aws:iam::123456789012:instance-profile/%s", req.RoleName),
+		policies: make(map[string]string),
+	}
+	s.roles[req.RoleName] = rl
+
+	writeJSON(w, map[string]interface{}{
+		"roleName":                   req.RoleName,
+		"roleType":                   req.RoleType,
+		"roleArn":                    rl.arn,
+		"instanceProfileArn":         rl.ipArn,
+		"addedRoleToInstanceProfile": true,
+	})
+}
+
+func (s *Server) handleGetAccountRole(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		RoleName string `json:"roleName"`
+	}
+	if err := decodeJSON(r, &req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	s.mu.Lock()
+	rl, ok := s.roles[req.RoleName]
+	s.mu.Unlock()
+
+	if !ok {
+		writeJSON(w, map[string]interface{}{"roleName": req.RoleName, "roleExists": false})
+		return
+	}
+
+	writeJSON(w, map[string]interface{}{
+		"roleName":           req.RoleName,
+		"roleArn":            rl.arn,
+		"instanceProfileArn": rl.ipArn,
+		"roleExists":         true,
+	})
+}
+
+func (s *Server) handleDeleteRole(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		RoleName string `json:"roleName"`
+	}
+	if err := decodeJSON(r, &req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	s.mu.Lock()
+	_, ok := s.roles[req.RoleName]
+	delete(s.roles, req.RoleName)
+	s.mu.Unlock()
+
+	if !ok {
+		writeJSON(w, map[string]interface{}{
+			"roleName": req.RoleName,
+			"errors":   []string{"role does not exist"},
+		})
+		return
+	}
+
+	writeJSON(w, map[string]interface{}{"roleName": req.RoleName})
+}
+
+func (s *Server) handleAttachRolePolicy(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		RoleName       string `json:"roleName"`
+		PolicyName     string `json:"policyName"`
+		PolicyDocument string `json:"policyDocument"`
+	}
+	if err := decodeJSON(r, &req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	s.mu.Lock()
+	rl, ok := s.roles[req.RoleName]
+	if ok {
+		rl.policies[req.PolicyName] = req.PolicyDocument
+	}
+	s.mu.Unlock()
+
+	if !ok {
+		writeJSON(w, map[string]interface{}{"errors": []string{"NoSuchEntity: role does not exist"}})
+		return
+	}
+
+	writeJSON(w, map[string]interface{}{"roleName": req.RoleName, "policyName": req.PolicyName})
+}
+
+func (s *Server) handleGetRolePolicy(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		RoleName   string `json:"roleName"`
+		PolicyName string `json:"policyName"`
+	}
+	if err := decodeJSON(r, &req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	s.mu.Lock()
+	rl, ok := s.roles[req.RoleName]
+	var doc string
+	if ok {
+		doc, ok = rl.policies[req.PolicyName]
+	}
+	s.mu.Unlock()
+
+	if !ok {
+		writeJSON(w, map[string]interface{}{"roleName": req.RoleName, "policyName": req.PolicyName, "policyExists": false})
+		return
+	}
+
+	writeJSON(w, map[string]interface{}{
+		"roleName":       req.RoleName,
+		"policyName":     req.PolicyName,
+		"policyDocument": url.QueryEscape(doc),
+		"policyExists":   true,
+	})
+}
+
+func (s *Server) handleDetachRolePolicy(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		RoleName   string `json:"roleName"`
+		PolicyName string `json:"policyName"`
+	}
+	if err := decodeJSON(r, &req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	s.mu.Lock()
+	rl, ok := s.roles[req.RoleName]
+	if ok {
+		delete(rl.policies, req.PolicyName)
+	}
+	s.mu.Unlock()
+
+	writeJSON(w, map[string]interface{}{"roleName": req.RoleName, "policyName": req.PolicyName})
+}
+
+func (s *Server) handleListRolePolicies(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		RoleName string `json:"roleName"`
+	}
+	if err := decodeJSON(r, &req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	s.mu.Lock()
+	rl, ok := s.roles[req.RoleName]
+	var names []string
+	if ok {
+		for name := range rl.policies {
+			names = append(names, name)
+		}
+	}
+	s.mu.Unlock()
+
+	writeJSON(w, map[string]interface{}{"roleName": req.RoleName, "policyNames": names})
+}
+
+func (s *Server) handleCreateAccessKeys(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		IamUserName string `json:"iamUserName"`
+	}
+	if err := decodeJSON(r, &req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	lt := &ltk{
+		accessKey: fmt.Sprintf("AKIAFAKELTK%d", len(s.ltks)),
+		secretKey: fmt.Sprintf("ltksecret%d", len(s.ltks)),
+		arn:       fmt.Sprintf("arn:aws:iam::123456789012:user/%s", req.IamUserName),
+	}
+	s.ltks[req.IamUserName] = lt
+
+	writeJSON(w, map[string]interface{}{
+		"iamUserName":   req.IamUserName,
+		"accessKey":     lt.accessKey,
+		"secretKey":     lt.secretKey,
+		"iamUserArn":    lt.arn,
+		"iamUserExists": true,
+	})
+}
+
+func (s *Server) handleGetAccessKeys(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		IamUserName string `json:"iamUserName"`
+	}
+	if err := decodeJSON(r, &req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	s.mu.Lock()
+	lt, ok := s.ltks[req.IamUserName]
+	s.mu.Unlock()
+
+	if !ok {
+		writeJSON(w, map[string]interface{}{"iamUserName": req.IamUserName, "iamUserExists": false})
+		return
+	}
+
+	writeJSON(w, map[string]interface{}{
+		"iamUserName":   req.IamUserName,
+		"accessKey":     lt.accessKey,
+		"iamUserArn":    lt.arn,
+		"iamUserExists": true,
+	})
+}
+
+func (s *Server) handleDeleteAccessKeys(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		IamUserName string `json:"iamUserName"`
+	}
+	if err := decodeJSON(r, &req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	s.mu.Lock()
+	delete(s.ltks, req.IamUserName)
+	s.mu.Unlock()
+
+	writeJSON(w, map[string]interface{}{"iamUserName": req.IamUserName})
+}
@@ -0,0 +1,104 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func Provider() terraform.ResourceProvider {
+	return &schema.Provider{
+		Schema: map[string]*schema.Schema{
+			"url": {
+				Type:        schema.TypeString,
+				Required:    true,
+				DefaultFunc: schema.EnvDefaultFunc("ALKS_URL", nil),
+			},
+			"username": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				DefaultFunc:   schema.EnvDefaultFunc("ALKS_USERNAME", nil),
+				ConflictsWith: []string{"key_id", "use_ssh_agent"},
+			},
+			"password": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Sensitive:     true,
+				DefaultFunc:   schema.EnvDefaultFunc("ALKS_PASSWORD", nil),
+				ConflictsWith: []string{"key_id", "use_ssh_agent"},
+			},
+			"account": {
+				Type:        schema.TypeString,
+				Required:    true,
+				DefaultFunc: schema.EnvDefaultFunc("ALKS_ACCOUNT", nil),
+			},
+			"role": {
+				Type:        schema.TypeString,
+				Required:    true,
+				DefaultFunc: schema.EnvDefaultFunc("ALKS_ROLE", nil),
+			},
+			"max_retries": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("ALKS_MAX_RETRIES", defaultMaxRetries),
+				ValidateFunc: validateNonNegativeInt,
+			},
+			"key_id": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				DefaultFunc:   schema.EnvDefaultFunc("ALKS_KEY_ID", nil),
+				ConflictsWith: []string{"username", "password"},
+			},
+			"key_material": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Sensitive:     true,
+				DefaultFunc:   schema.EnvDefaultFunc("ALKS_KEY_MATERIAL", nil),
+				ConflictsWith: []string{"username", "password"},
+			},
+			"use_ssh_agent": {
+				Type:          schema.TypeBool,
+				Optional:      true,
+				Default:       false,
+				ConflictsWith: []string{"username", "password"},
+			},
+		},
+		ResourcesMap: map[string]*schema.Resource{
+			"alks_iam_role":        resourceAlksIamRole(),
+			"alks_iam_role_policy": resourceAlksIamRolePolicy(),
+			"alks_ltk":             resourceAlksLtk(),
+		},
+		ConfigureFunc: providerConfigure,
+	}
+}
+
+func validateNonNegativeInt(v interface{}, k string) (ws []string, errors []error) {
+	if value := v.(int); value < 0 {
+		errors = append(errors, fmt.Errorf("%q must be >= 0, got %d", k, value))
+	}
+	return
+}
+
+func providerConfigure(d *schema.ResourceData) (interface{}, error) {
+	var auth AuthProvider
+
+	if keyID, ok := d.GetOk("key_id"); ok {
+		auth = SSHAgentAuth{
+			KeyID:       keyID.(string),
+			KeyMaterial: d.Get("key_material").(string),
+		}
+	} else if d.Get("use_ssh_agent").(bool) {
+		return nil, fmt.Errorf("use_ssh_agent requires key_id to select which agent key to sign with")
+	}
+
+	return NewAlksClient(
+		d.Get("url").(string),
+		d.Get("username").(string),
+		d.Get("password").(string),
+		d.Get("account").(string),
+		d.Get("role").(string),
+		d.Get("max_retries").(int),
+		auth,
+	)
+}
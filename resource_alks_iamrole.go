@@ -0,0 +1,141 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func resourceAlksIamRole() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAlksIamRoleCreate,
+		Read:   resourceAlksIamRoleRead,
+		Delete: resourceAlksIamRoleDelete,
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"type": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"include_default_policies": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+				ForceNew: true,
+			},
+			"assume_role_policy": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validateJSONString,
+			},
+			"max_session_duration": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				ForceNew: true,
+			},
+			"arn": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"instance_profile_arn": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceAlksIamRoleCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*AlksClient)
+
+	var resp *CreateRoleResponse
+	var err error
+
+	if policy, ok := d.GetOk("assume_role_policy"); ok {
+		resp, err = client.CreateIamTrustRole(
+			context.Background(),
+			d.Get("name").(string),
+			d.Get("type").(string),
+			policy.(string),
+			d.Get("max_session_duration").(int),
+			d.Get("include_default_policies").(bool),
+		)
+	} else {
+		resp, err = client.CreateIamRole(
+			context.Background(),
+			d.Get("name").(string),
+			d.Get("type").(string),
+			d.Get("include_default_policies").(bool),
+		)
+	}
+
+	if err != nil {
+		return fmt.Errorf("Error creating IAM role: %s", err)
+	}
+
+	d.SetId(resp.RoleName)
+	d.Set("arn", resp.RoleArn)
+	d.Set("instance_profile_arn", resp.RoleIPArn)
+
+	return nil
+}
+
+// validateJSONString checks that a schema attribute holds syntactically
+// valid JSON, matching the plan-time check the AWS provider runs on
+// assume_role_policy before it ever reaches the API.
+func validateJSONString(v interface{}, k string) (ws []string, errors []error) {
+	if _, err := normalizeJSONString(v); err != nil {
+		errors = append(errors, fmt.Errorf("%q contains an invalid JSON: %s", k, err))
+	}
+	return
+}
+
+func normalizeJSONString(v interface{}) (string, error) {
+	var j interface{}
+
+	s, ok := v.(string)
+	if !ok {
+		return "", fmt.Errorf("expected a JSON string, got %T", v)
+	}
+
+	if err := json.Unmarshal([]byte(s), &j); err != nil {
+		return "", err
+	}
+
+	return s, nil
+}
+
+func resourceAlksIamRoleRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*AlksClient)
+
+	resp, err := client.GetIamRole(context.Background(), d.Id())
+	if err != nil {
+		return fmt.Errorf("Error reading IAM role: %s", err)
+	}
+
+	if resp == nil {
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("name", resp.RoleName)
+	d.Set("arn", resp.RoleArn)
+	d.Set("instance_profile_arn", resp.RoleIPArn)
+
+	return nil
+}
+
+func resourceAlksIamRoleDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*AlksClient)
+
+	return client.DeleteIamRole(context.Background(), d, meta)
+}
@@ -0,0 +1,178 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/kerryhatcher/terraform-provider-alks/alkstest"
+)
+
+func TestAccAlksIamRole_basic(t *testing.T) {
+	srv := alkstest.NewServer()
+	defer srv.Close()
+
+	providers := map[string]terraform.ResourceProvider{
+		"alks": Provider(),
+	}
+
+	roleName := "tf-acc-test-role"
+
+	resource.Test(t, resource.TestCase{
+		IsUnitTest:   true,
+		Providers:    providers,
+		CheckDestroy: testAccCheckAlksIamRoleDestroy(providers, roleName),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAlksIamRoleConfig(srv.URL, roleName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAlksIamRoleExists("alks_iam_role.test", providers),
+					resource.TestCheckResourceAttr("alks_iam_role.test", "name", roleName),
+				),
+			},
+		},
+	})
+
+	if len(srv.Actions()) == 0 {
+		t.Fatal("expected the fake server to record at least one request")
+	}
+}
+
+func TestAccAlksIamRole_createRoleFault(t *testing.T) {
+	srv := alkstest.NewServer()
+	defer srv.Close()
+	srv.SetError("/createRole/", 500)
+
+	providers := map[string]terraform.ResourceProvider{
+		"alks": Provider(),
+	}
+
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  providers,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccAlksIamRoleConfig(srv.URL, "tf-acc-test-fault"),
+				ExpectError: regexp.MustCompile(`Error creating IAM role: API Error 500`),
+			},
+		},
+	})
+}
+
+// TestAlksClient_createIamRoleRetries confirms doRequest actually retries
+// MaxRetries additional times (not zero, not once) against a
+// persistently-failing endpoint before giving up.
+func TestAlksClient_createIamRoleRetries(t *testing.T) {
+	srv := alkstest.NewServer()
+	defer srv.Close()
+	srv.SetError("/createRole/", 500)
+
+	client, err := NewAlksClient(srv.URL, "test", "test", "123456789012", "Admin", 2, nil)
+	if err != nil {
+		t.Fatalf("NewAlksClient: %s", err)
+	}
+
+	if _, err := client.CreateIamRole(context.Background(), "tf-acc-test-retry", "Amazon EC2", true); err == nil {
+		t.Fatal("expected CreateIamRole to fail against a persistently-faulting server")
+	}
+
+	var createAttempts int
+	for _, action := range srv.Actions() {
+		if action == "/createRole/" {
+			createAttempts++
+		}
+	}
+
+	if want := 2 + 1; createAttempts != want {
+		t.Fatalf("got %d /createRole/ attempts, want %d (MaxRetries+1)", createAttempts, want)
+	}
+}
+
+// TestAlksClient_deleteIamRoleIdempotent confirms a second delete of an
+// already-gone role is a no-op rather than an error, matching Terraform's
+// expectation that Destroy is idempotent.
+func TestAlksClient_deleteIamRoleIdempotent(t *testing.T) {
+	srv := alkstest.NewServer()
+	defer srv.Close()
+
+	client, err := NewAlksClient(srv.URL, "test", "test", "123456789012", "Admin", 0, nil)
+	if err != nil {
+		t.Fatalf("NewAlksClient: %s", err)
+	}
+
+	d := schema.TestResourceDataRaw(t, resourceAlksIamRole().Schema, map[string]interface{}{
+		"name": "tf-acc-test-already-gone",
+		"type": "Amazon EC2",
+	})
+	d.SetId("tf-acc-test-already-gone")
+
+	if err := client.DeleteIamRole(context.Background(), d, client); err != nil {
+		t.Fatalf("first delete of a nonexistent role should be a no-op, got: %s", err)
+	}
+
+	if err := client.DeleteIamRole(context.Background(), d, client); err != nil {
+		t.Fatalf("second delete of an already-deleted role should be a no-op, got: %s", err)
+	}
+}
+
+func testAccAlksClient(providers map[string]terraform.ResourceProvider) *AlksClient {
+	return providers["alks"].(*schema.Provider).Meta().(*AlksClient)
+}
+
+func testAccCheckAlksIamRoleExists(n string, providers map[string]terraform.ResourceProvider) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No IAM role ID is set")
+		}
+
+		role, err := testAccAlksClient(providers).GetIamRole(context.Background(), rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+		if role == nil {
+			return fmt.Errorf("IAM role %s does not exist", rs.Primary.ID)
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckAlksIamRoleDestroy(providers map[string]terraform.ResourceProvider, roleName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		role, err := testAccAlksClient(providers).GetIamRole(context.Background(), roleName)
+		if err != nil {
+			return err
+		}
+		if role != nil {
+			return fmt.Errorf("IAM role %s still exists", roleName)
+		}
+
+		return nil
+	}
+}
+
+func testAccAlksIamRoleConfig(url string, roleName string) string {
+	return fmt.Sprintf(`
+provider "alks" {
+  url      = %q
+  username = "test"
+  password = "test"
+  account  = "123456789012"
+  role     = "Admin"
+}
+
+resource "alks_iam_role" "test" {
+  name = %q
+  type = "Amazon EC2"
+}
+`, url, roleName)
+}
@@ -0,0 +1,161 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func resourceAlksIamRolePolicy() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAlksIamRolePolicyCreate,
+		Read:   resourceAlksIamRolePolicyRead,
+		Update: resourceAlksIamRolePolicyPut,
+		Delete: resourceAlksIamRolePolicyDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"name_prefix"},
+				ValidateFunc:  validateIamRolePolicyName,
+			},
+			"name_prefix": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validateIamRolePolicyNamePrefix,
+			},
+			"policy": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"role": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+		},
+	}
+}
+
+func resourceAlksIamRolePolicyCreate(d *schema.ResourceData, meta interface{}) error {
+	var name string
+	if v, ok := d.GetOk("name"); ok {
+		name = v.(string)
+	} else if v, ok := d.GetOk("name_prefix"); ok {
+		name = resource.PrefixedUniqueId(v.(string))
+	} else {
+		name = resource.UniqueId()
+	}
+
+	if err := resourceAlksIamRolePolicyPutWithName(d, meta, d.Get("role").(string), name); err != nil {
+		return err
+	}
+
+	d.SetId(fmt.Sprintf("%s:%s", d.Get("role").(string), name))
+
+	return resourceAlksIamRolePolicyRead(d, meta)
+}
+
+func resourceAlksIamRolePolicyPut(d *schema.ResourceData, meta interface{}) error {
+	role, name, err := resourceAlksIamRolePolicyParseId(d.Id())
+	if err != nil {
+		return err
+	}
+
+	return resourceAlksIamRolePolicyPutWithName(d, meta, role, name)
+}
+
+func resourceAlksIamRolePolicyPutWithName(d *schema.ResourceData, meta interface{}, role string, name string) error {
+	client := meta.(*AlksClient)
+
+	_, err := client.AttachRolePolicy(context.Background(), role, name, d.Get("policy").(string))
+	if err != nil {
+		return fmt.Errorf("Error attaching IAM role policy: %s", err)
+	}
+
+	return nil
+}
+
+func resourceAlksIamRolePolicyRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*AlksClient)
+
+	role, name, err := resourceAlksIamRolePolicyParseId(d.Id())
+	if err != nil {
+		return err
+	}
+
+	policy, err := client.GetRolePolicy(context.Background(), role, name)
+	if err != nil {
+		return fmt.Errorf("Error reading IAM role policy: %s", err)
+	}
+
+	if policy == nil {
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("name", policy.PolicyName)
+	d.Set("role", policy.RoleName)
+	d.Set("policy", policy.PolicyDocument)
+
+	return nil
+}
+
+func resourceAlksIamRolePolicyDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*AlksClient)
+
+	role, name, err := resourceAlksIamRolePolicyParseId(d.Id())
+	if err != nil {
+		return err
+	}
+
+	if err := client.DetachRolePolicy(context.Background(), role, name); err != nil {
+		return fmt.Errorf("Error deleting IAM role policy: %s", err)
+	}
+
+	return nil
+}
+
+func resourceAlksIamRolePolicyParseId(id string) (roleName, policyName string, err error) {
+	parts := strings.SplitN(id, ":", 2)
+	if len(parts) != 2 {
+		return "", "", fmt.Errorf("Unexpected ID format for alks_iam_role_policy (%q), expected ROLE:POLICY", id)
+	}
+
+	return parts[0], parts[1], nil
+}
+
+var validRolePolicyNameRegex = regexp.MustCompile(`^[\w+=,.@-]+$`)
+
+func validateIamRolePolicyName(v interface{}, k string) (ws []string, errors []error) {
+	value := v.(string)
+	if len(value) > 128 {
+		errors = append(errors, fmt.Errorf("%q cannot be longer than 128 characters", k))
+	}
+	if !validRolePolicyNameRegex.MatchString(value) {
+		errors = append(errors, fmt.Errorf("%q must match [\\w+=,.@-]+", k))
+	}
+	return
+}
+
+func validateIamRolePolicyNamePrefix(v interface{}, k string) (ws []string, errors []error) {
+	value := v.(string)
+	if len(value) > 128-resource.UniqueIDSuffixLength {
+		errors = append(errors, fmt.Errorf("%q cannot be longer than %d characters", k, 128-resource.UniqueIDSuffixLength))
+	}
+	if !validRolePolicyNameRegex.MatchString(value) {
+		errors = append(errors, fmt.Errorf("%q must match [\\w+=,.@-]+", k))
+	}
+	return
+}
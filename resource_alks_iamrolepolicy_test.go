@@ -0,0 +1,100 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/kerryhatcher/terraform-provider-alks/alkstest"
+)
+
+func TestAccAlksIamRolePolicy_basic(t *testing.T) {
+	srv := alkstest.NewServer()
+	defer srv.Close()
+
+	providers := map[string]terraform.ResourceProvider{
+		"alks": Provider(),
+	}
+
+	roleName := "tf-acc-test-role-policy"
+	policyName := "tf-acc-test-policy"
+
+	resource.Test(t, resource.TestCase{
+		IsUnitTest:   true,
+		Providers:    providers,
+		CheckDestroy: testAccCheckAlksIamRolePolicyDestroy(providers, roleName, policyName),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAlksIamRolePolicyConfig(srv.URL, roleName, policyName, `{"Version":"2012-10-17","Statement":[]}`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAlksIamRolePolicyExists("alks_iam_role_policy.test", providers),
+					resource.TestCheckResourceAttr("alks_iam_role_policy.test", "name", policyName),
+					resource.TestCheckResourceAttr("alks_iam_role_policy.test", "role", roleName),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckAlksIamRolePolicyExists(n string, providers map[string]terraform.ResourceProvider) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		role, name, err := resourceAlksIamRolePolicyParseId(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		policy, err := testAccAlksClient(providers).GetRolePolicy(context.Background(), role, name)
+		if err != nil {
+			return err
+		}
+		if policy == nil {
+			return fmt.Errorf("IAM role policy %s does not exist", rs.Primary.ID)
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckAlksIamRolePolicyDestroy(providers map[string]terraform.ResourceProvider, roleName string, policyName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		policy, err := testAccAlksClient(providers).GetRolePolicy(context.Background(), roleName, policyName)
+		if err != nil {
+			return err
+		}
+		if policy != nil {
+			return fmt.Errorf("IAM role policy %s:%s still exists", roleName, policyName)
+		}
+
+		return nil
+	}
+}
+
+func testAccAlksIamRolePolicyConfig(url string, roleName string, policyName string, policyDocument string) string {
+	return fmt.Sprintf(`
+provider "alks" {
+  url      = %q
+  username = "test"
+  password = "test"
+  account  = "123456789012"
+  role     = "Admin"
+}
+
+resource "alks_iam_role" "test" {
+  name = %q
+  type = "Amazon EC2"
+}
+
+resource "alks_iam_role_policy" "test" {
+  name   = %q
+  role   = alks_iam_role.test.name
+  policy = %q
+}
+`, url, roleName, policyName, policyDocument)
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func resourceAlksLtk() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAlksLtkCreate,
+		Read:   resourceAlksLtkRead,
+		Delete: resourceAlksLtkDelete,
+
+		Schema: map[string]*schema.Schema{
+			"iam_username": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"access_key": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"secret_key": {
+				Type:      schema.TypeString,
+				Computed:  true,
+				Sensitive: true,
+			},
+			"iam_user_arn": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceAlksLtkCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*AlksClient)
+
+	resp, err := client.CreateLongTermKey(context.Background(), d.Get("iam_username").(string))
+	if err != nil {
+		return fmt.Errorf("Error creating long-term IAM key: %s", err)
+	}
+
+	d.SetId(resp.IamUserName)
+	d.Set("access_key", resp.AccessKey)
+	d.Set("secret_key", resp.SecretKey)
+	d.Set("iam_user_arn", resp.IamUserArn)
+
+	return nil
+}
+
+// resourceAlksLtkRead confirms the IAM user still exists and refreshes
+// access_key/iam_user_arn. ALKS hands back secret_key only once, at
+// creation, so Read never touches it and it stays exactly as Create
+// left it in state.
+func resourceAlksLtkRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*AlksClient)
+
+	resp, err := client.GetLongTermKey(context.Background(), d.Id())
+	if err != nil {
+		return fmt.Errorf("Error reading long-term IAM key: %s", err)
+	}
+
+	if resp == nil {
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("iam_username", resp.IamUserName)
+	d.Set("access_key", resp.AccessKey)
+	d.Set("iam_user_arn", resp.IamUserArn)
+
+	return nil
+}
+
+func resourceAlksLtkDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*AlksClient)
+
+	if err := client.DeleteLongTermKey(context.Background(), d.Id()); err != nil {
+		return fmt.Errorf("Error deleting long-term IAM key: %s", err)
+	}
+
+	return nil
+}
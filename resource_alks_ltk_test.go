@@ -0,0 +1,136 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/kerryhatcher/terraform-provider-alks/alkstest"
+)
+
+func TestAccAlksLtk_basic(t *testing.T) {
+	srv := alkstest.NewServer()
+	defer srv.Close()
+
+	providers := map[string]terraform.ResourceProvider{
+		"alks": Provider(),
+	}
+
+	iamUserName := "tf-acc-test-ltk"
+	var secretKey string
+
+	resource.Test(t, resource.TestCase{
+		IsUnitTest:   true,
+		Providers:    providers,
+		CheckDestroy: testAccCheckAlksLtkDestroy(providers, iamUserName),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAlksLtkConfig(srv.URL, iamUserName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAlksLtkExists("alks_ltk.test", providers),
+					resource.TestCheckResourceAttr("alks_ltk.test", "iam_username", iamUserName),
+					resource.TestCheckResourceAttrSet("alks_ltk.test", "access_key"),
+					testAccCheckAlksLtkCaptureSecret("alks_ltk.test", &secretKey),
+				),
+			},
+			{
+				// A second, no-op apply forces a Read/refresh. secret_key
+				// must survive unchanged since ALKS only ever returns it
+				// once, at creation.
+				Config: testAccAlksLtkConfig(srv.URL, iamUserName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAlksLtkExists("alks_ltk.test", providers),
+					testAccCheckAlksLtkSecretUnchanged("alks_ltk.test", &secretKey),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckAlksLtkExists(n string, providers map[string]terraform.ResourceProvider) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No long-term IAM key ID is set")
+		}
+
+		resp, err := testAccAlksClient(providers).GetLongTermKey(context.Background(), rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+		if resp == nil {
+			return fmt.Errorf("long-term IAM key %s does not exist", rs.Primary.ID)
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckAlksLtkDestroy(providers map[string]terraform.ResourceProvider, iamUserName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		resp, err := testAccAlksClient(providers).GetLongTermKey(context.Background(), iamUserName)
+		if err != nil {
+			return err
+		}
+		if resp != nil {
+			return fmt.Errorf("long-term IAM key %s still exists", iamUserName)
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckAlksLtkCaptureSecret(n string, out *string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		secret := rs.Primary.Attributes["secret_key"]
+		if secret == "" {
+			return fmt.Errorf("secret_key was not set")
+		}
+		*out = secret
+
+		return nil
+	}
+}
+
+func testAccCheckAlksLtkSecretUnchanged(n string, want *string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		got := rs.Primary.Attributes["secret_key"]
+		if got != *want {
+			return fmt.Errorf("secret_key changed across refresh: got %q, want %q", got, *want)
+		}
+
+		return nil
+	}
+}
+
+func testAccAlksLtkConfig(url string, iamUserName string) string {
+	return fmt.Sprintf(`
+provider "alks" {
+  url      = %q
+  username = "test"
+  password = "test"
+  account  = "123456789012"
+  role     = "Admin"
+}
+
+resource "alks_ltk" "test" {
+  iam_username = %q
+}
+`, url, iamUserName)
+}